@@ -3,6 +3,7 @@ package events
 import (
 	"strings"
 	"sync"
+	"time"
 )
 
 type Listener chan Event
@@ -11,18 +12,348 @@ type Listener chan Event
 type Event struct {
 	Topic string
 	Data  interface{}
+	// Seq is a monotonically increasing sequence number assigned by the
+	// Bus at publish time. Clients can persist the Seq of the last event
+	// they processed and pass it to SubscribeFrom as a cursor to resume
+	// without missing or re-processing events across a reconnect.
+	Seq uint64
 }
 
+// Policy controls how a listener's bounded queue behaves once it is full.
+type Policy int
+
+const (
+	// PolicyBlock makes the listener's accept goroutine wait until its
+	// queue has room for the event instead of dropping it. Publish itself
+	// never waits: once the listener's staging inbox is also full, the
+	// oldest staged event is dropped. This is the closest equivalent to
+	// the Bus' historical behavior and is the default used by On.
+	PolicyBlock Policy = iota
+	// PolicyDropOldest discards the oldest queued event to make room for
+	// the new one.
+	PolicyDropOldest
+	// PolicyDropNewest discards the incoming event when the queue is full,
+	// leaving whatever is already queued untouched.
+	PolicyDropNewest
+	// PolicyCoalesce keeps only the most recently published event, so a
+	// slow listener always sees the latest value rather than a backlog.
+	// Intended for high-frequency topics such as "stats" or
+	// "console output" where only the newest state matters.
+	PolicyCoalesce
+)
+
+// defaultQueueSize is the bounded queue depth used when a listener is
+// registered without an explicit capacity.
+const defaultQueueSize = 16
+
+// ListenerMetrics exposes a listener's current backpressure state so
+// operators can diagnose slow consumers, such as a stalled websocket.
+type ListenerMetrics struct {
+	Dropped    uint64
+	QueueDepth int
+	LastDrop   time.Time
+}
+
+// subscriber owns the bounded queue and pump goroutine for a single
+// registered Listener, decoupling Publish from the listener's consumption
+// rate instead of spawning a goroutine per event.
+//
+// Applying the queue's policy (in particular PolicyBlock's wait for room)
+// happens on a dedicated per-subscriber goroutine (accept), never on the
+// caller of Publish. push itself never waits: it only ever stages events
+// in inbox, a second bounded buffer accept drains from, so one stalled
+// PolicyBlock listener can make its own accept goroutine wait indefinitely
+// without ever blocking Publish, or delivery to any other listener on the
+// same topic. inbox is capped at the same capacity as queue rather than
+// left unbounded: once accept can't keep up (in practice, a permanently
+// stalled PolicyBlock listener), push applies the listener's policy to
+// inbox, except that PolicyBlock drops the oldest staged event to make
+// room for the newest, the same way PolicyDropOldest does. That drop is folded into
+// Dropped/QueueDepth alongside queue's, so a stalled listener is still
+// visible in Metrics instead of silently growing memory forever.
+type subscriber struct {
+	listener Listener
+	policy   Policy
+	capacity int
+
+	mu     sync.Mutex
+	cond   *sync.Cond
+	queue  []Event
+	closed bool
+
+	// statsMu guards dropped/lastDrop, since both push (holding inboxMu)
+	// and queuePush (holding mu) need to update them and neither of those
+	// locks is held by both.
+	statsMu  sync.Mutex
+	dropped  uint64
+	lastDrop time.Time
+
+	// inboxMu/inboxCond/inbox/inboxClosed form the bounded hand-off from
+	// push to accept; see push. inboxClosed is a separate flag from closed
+	// above, since the two are guarded by different mutexes.
+	inboxMu     sync.Mutex
+	inboxCond   *sync.Cond
+	inbox       []Event
+	inboxClosed bool
+
+	// done is closed by close() to tell the pump to stop, including
+	// unblocking it if it's currently stuck trying to send to listener.
+	done chan struct{}
+	// stopped is closed by the pump right before it returns. close()
+	// waits on it so that, once close() returns, nothing will ever send
+	// to listener again and it's safe for the caller to close(listener).
+	stopped chan struct{}
+	// acceptStopped is closed by accept right before it returns. close()
+	// waits on it too, so that once close() returns, accept is guaranteed
+	// to never call queuePush again.
+	acceptStopped chan struct{}
+}
+
+func newSubscriber(listener Listener, policy Policy, capacity int) *subscriber {
+	if capacity < 1 {
+		capacity = defaultQueueSize
+	}
+	if policy == PolicyCoalesce {
+		// Coalesce means "only the latest event matters", so there is
+		// never more than one event worth keeping queued.
+		capacity = 1
+	}
+	s := &subscriber{
+		listener:      listener,
+		policy:        policy,
+		capacity:      capacity,
+		done:          make(chan struct{}),
+		stopped:       make(chan struct{}),
+		acceptStopped: make(chan struct{}),
+	}
+	s.cond = sync.NewCond(&s.mu)
+	s.inboxCond = sync.NewCond(&s.inboxMu)
+	go s.accept()
+	go s.pump()
+	return s
+}
+
+// push hands event off to the subscriber without ever blocking, regardless
+// of policy: it only ever appends to inbox, which the accept goroutine
+// drains. Publish calls this directly (in the calling goroutine, in
+// topic-subscriber order) for every matching subscriber, so a slow
+// PolicyBlock listener must never be allowed to wait here - that wait
+// belongs to accept instead, or it would stall Publish itself and, with
+// it, delivery to every other subscriber on the topic.
+//
+// inbox is still bounded to capacity, though, so a listener whose accept
+// goroutine is stuck waiting for queue room can't grow inbox without
+// limit. Once full, push applies the listener's own policy the same way
+// queuePush does on the queue below - preserving its drop contract (e.g.
+// PolicyDropNewest still discards the incoming event rather than the
+// oldest staged one) - except PolicyBlock, which can't wait here without
+// reintroducing the exact stall this exists to avoid, so it falls back to
+// dropping the oldest staged event instead.
+func (s *subscriber) push(event Event) {
+	s.inboxMu.Lock()
+	defer s.inboxMu.Unlock()
+
+	if s.inboxClosed {
+		return
+	}
+	if len(s.inbox) >= s.capacity {
+		switch s.policy {
+		case PolicyDropNewest:
+			s.recordDrop()
+			return
+		case PolicyCoalesce:
+			s.inbox[len(s.inbox)-1] = event
+			s.inboxCond.Signal()
+			return
+		default: // PolicyDropOldest, PolicyBlock
+			s.inbox = s.inbox[1:]
+			s.recordDrop()
+		}
+	}
+	s.inbox = append(s.inbox, event)
+	s.inboxCond.Signal()
+}
+
+// recordDrop records that an event was dropped, for Metrics. Safe to call
+// from push (holding inboxMu) or queuePush (holding mu), since it's
+// guarded by its own lock rather than either of those.
+func (s *subscriber) recordDrop() {
+	s.statsMu.Lock()
+	s.dropped++
+	s.lastDrop = time.Now()
+	s.statsMu.Unlock()
+}
+
+// accept drains inbox in order and applies the subscriber's policy to move
+// each event into the bounded queue pump delivers from,
+// waiting for room under PolicyBlock if needed. This is the only goroutine
+// that can block on queue room, so it's the only thing a stalled
+// PolicyBlock listener ever stalls.
+func (s *subscriber) accept() {
+	defer close(s.acceptStopped)
+	for {
+		s.inboxMu.Lock()
+		for len(s.inbox) == 0 && !s.inboxClosed {
+			s.inboxCond.Wait()
+		}
+		if len(s.inbox) == 0 && s.inboxClosed {
+			s.inboxMu.Unlock()
+			return
+		}
+		event := s.inbox[0]
+		s.inbox = s.inbox[1:]
+		s.inboxMu.Unlock()
+
+		s.queuePush(event)
+	}
+}
+
+// queuePush enqueues event onto the bounded queue pump delivers from,
+// applying the subscriber's policy if the queue is already at capacity.
+func (s *subscriber) queuePush(event Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for len(s.queue) >= s.capacity {
+		if s.closed {
+			return
+		}
+		switch s.policy {
+		case PolicyDropNewest:
+			s.recordDrop()
+			return
+		case PolicyDropOldest:
+			s.queue = s.queue[1:]
+			s.recordDrop()
+		case PolicyCoalesce:
+			s.queue[len(s.queue)-1] = event
+			s.cond.Signal()
+			return
+		default: // PolicyBlock
+			s.cond.Wait()
+			continue
+		}
+	}
+	if s.closed {
+		return
+	}
+
+	s.queue = append(s.queue, event)
+	s.cond.Signal()
+}
+
+// pump delivers queued events to the listener channel one at a time,
+// blocking on the (potentially slow) listener outside of the queue lock so
+// other listeners are never held up by it. It may still deliver queued
+// events while close() is running, but never sends to listener after
+// close() has returned, so the caller can safely close(listener) once
+// close() returns without risking a send on a closed channel.
+func (s *subscriber) pump() {
+	defer close(s.stopped)
+	for {
+		s.mu.Lock()
+		for len(s.queue) == 0 && !s.closed {
+			s.cond.Wait()
+		}
+		if len(s.queue) == 0 && s.closed {
+			s.mu.Unlock()
+			return
+		}
+		event := s.queue[0]
+		s.queue = s.queue[1:]
+		s.cond.Signal()
+		s.mu.Unlock()
+
+		select {
+		case s.listener <- event:
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// metrics reports QueueDepth as everything currently buffered for the
+// listener, queue and inbox combined, so a stalled PolicyBlock consumer
+// backing up into inbox is still visible here rather than looking idle.
+func (s *subscriber) metrics() ListenerMetrics {
+	s.mu.Lock()
+	depth := len(s.queue)
+	s.mu.Unlock()
+
+	s.inboxMu.Lock()
+	depth += len(s.inbox)
+	s.inboxMu.Unlock()
+
+	s.statsMu.Lock()
+	defer s.statsMu.Unlock()
+	return ListenerMetrics{Dropped: s.dropped, QueueDepth: depth, LastDrop: s.lastDrop}
+}
+
+// close stops the accept and pump goroutines and waits for them to
+// actually return, unblocking them first if they're in the middle of a
+// blocked send to listener or a blocked PolicyBlock wait for queue room.
+// Once close returns, nothing will send to listener again, so it is safe
+// for the caller to close(listener).
+func (s *subscriber) close() {
+	// Mark the queue closed (and unblock queuePush's PolicyBlock wait)
+	// before marking the inbox closed: accept may currently be sitting
+	// inside queuePush waiting for room, and that's the only wait that
+	// needs unblocking for accept to notice inboxClosed and exit on its
+	// next loop iteration.
+	s.mu.Lock()
+	s.closed = true
+	s.cond.Broadcast()
+	s.mu.Unlock()
+
+	s.inboxMu.Lock()
+	s.inboxClosed = true
+	s.inboxCond.Broadcast()
+	s.inboxMu.Unlock()
+	<-s.acceptStopped
+
+	close(s.done)
+	<-s.stopped
+}
+
+// wildcardAll, when passed to On/OnPolicy, subscribes a listener to every
+// topic published on the Bus.
+const wildcardAll = "*"
+
+// wildcardSuffix, appended to a topic passed to On/OnPolicy (for example
+// "backup completed:*"), subscribes a listener to that topic's namespace as
+// a whole, i.e. every "backup completed:<id>" regardless of <id>.
+const wildcardSuffix = ":*"
+
 // Bus represents an Event Bus.
 type Bus struct {
 	listenersMx sync.Mutex
-	listeners   map[string][]Listener
+	// exact holds listeners registered against a specific topic string,
+	// which may be a bare topic ("backup completed") or a fully qualified
+	// one ("backup completed:1234").
+	exact map[string][]Listener
+	// prefix holds listeners registered with a "<topic>:*" wildcard, keyed
+	// by <topic>.
+	prefix map[string][]Listener
+	// global holds listeners registered with the "*" wildcard.
+	global      []Listener
+	subscribers map[Listener]*subscriber
+
+	// seq is the last sequence number assigned to a published Event.
+	seq uint64
+	// logs holds the optional replay log for a topic, keyed by its base
+	// (unsuffixed) name. Guarded by listenersMx, like everything else on
+	// the Bus, so a SubscribeFrom's replay-then-live-delivery transition
+	// never races with Publish.
+	logs map[string]*replayLog
 }
 
 // NewBus returns a new empty Event Bus.
 func NewBus() *Bus {
 	return &Bus{
-		listeners: make(map[string][]Listener),
+		exact:       make(map[string][]Listener),
+		prefix:      make(map[string][]Listener),
+		subscribers: make(map[Listener]*subscriber),
+		logs:        make(map[string]*replayLog),
 	}
 }
 
@@ -36,6 +367,10 @@ func (b *Bus) Off(listener Listener, topics ...string) {
 	for _, topic := range topics {
 		ok := b.off(topic, listener)
 		if !closed && ok {
+			if sub, ok := b.subscribers[listener]; ok {
+				sub.close()
+				delete(b.subscribers, listener)
+			}
 			close(listener)
 			closed = true
 		}
@@ -43,7 +378,24 @@ func (b *Bus) Off(listener Listener, topics ...string) {
 }
 
 func (b *Bus) off(topic string, listener Listener) bool {
-	listeners, ok := b.listeners[topic]
+	switch {
+	case topic == wildcardAll:
+		for i, l := range b.global {
+			if l == listener {
+				b.global = append(b.global[:i], b.global[i+1:]...)
+				return true
+			}
+		}
+		return false
+	case strings.HasSuffix(topic, wildcardSuffix):
+		return removeListener(b.prefix, strings.TrimSuffix(topic, wildcardSuffix), listener)
+	default:
+		return removeListener(b.exact, topic, listener)
+	}
+}
+
+func removeListener(m map[string][]Listener, key string, listener Listener) bool {
+	listeners, ok := m[key]
 	if !ok {
 		return false
 	}
@@ -52,65 +404,123 @@ func (b *Bus) off(topic string, listener Listener) bool {
 			continue
 		}
 
-		listeners = append(listeners[:i], listeners[i+1:]...)
-		b.listeners[topic] = listeners
+		m[key] = append(listeners[:i], listeners[i+1:]...)
 		return true
 	}
 	return false
 }
 
-// On registers a listener to the specified topics on the Bus.
+// On registers a listener to the specified topics on the Bus using
+// PolicyBlock and the default queue size.
 func (b *Bus) On(listener Listener, topics ...string) {
+	b.OnPolicy(listener, PolicyBlock, topics...)
+}
+
+// OnPolicy registers a listener to the specified topics on the Bus, using
+// policy to decide how the listener's bounded queue behaves under
+// backpressure. Events are delivered by a single dedicated pump goroutine
+// per listener rather than a goroutine per event.
+func (b *Bus) OnPolicy(listener Listener, policy Policy, topics ...string) {
 	b.listenersMx.Lock()
 	defer b.listenersMx.Unlock()
 
+	if _, ok := b.subscribers[listener]; !ok {
+		b.subscribers[listener] = newSubscriber(listener, policy, defaultQueueSize)
+	}
 	for _, topic := range topics {
 		b.on(topic, listener)
 	}
 }
 
 func (b *Bus) on(topic string, listener Listener) {
-	listeners, ok := b.listeners[topic]
-	if !ok {
-		b.listeners[topic] = []Listener{listener}
-	} else {
-		b.listeners[topic] = append(listeners, listener)
+	switch {
+	case topic == wildcardAll:
+		b.global = append(b.global, listener)
+	case strings.HasSuffix(topic, wildcardSuffix):
+		base := strings.TrimSuffix(topic, wildcardSuffix)
+		b.prefix[base] = append(b.prefix[base], listener)
+	default:
+		b.exact[topic] = append(b.exact[topic], listener)
 	}
 }
 
+// baseTopic strips the ":suffix" namespace off of a topic, such as
+// "backup completed:1234", returning the standard listener name
+// ("backup completed") it is delivered under.
+func baseTopic(topic string) string {
+	if idx := strings.Index(topic, ":"); idx >= 0 {
+		return topic[:idx]
+	}
+	return topic
+}
+
+// matching returns, without duplicates, the subscribers registered for
+// topic: exact matches on the full topic and its base, "<base>:*" prefix
+// subscribers, and "*" global subscribers. Callers must hold listenersMx.
+func (b *Bus) matching(topic, base string) []*subscriber {
+	seen := make(map[Listener]struct{})
+	var subs []*subscriber
+	add := func(listeners []Listener) {
+		for _, l := range listeners {
+			if _, ok := seen[l]; ok {
+				continue
+			}
+			seen[l] = struct{}{}
+			if sub, ok := b.subscribers[l]; ok {
+				subs = append(subs, sub)
+			}
+		}
+	}
+
+	add(b.exact[topic])
+	if base != topic {
+		add(b.exact[base])
+	}
+	add(b.prefix[base])
+	add(b.global)
+
+	return subs
+}
+
 // Publish publishes a message to the Bus.
 func (b *Bus) Publish(topic string, data interface{}) {
 	// Some of our topics for the socket support passing a more specific namespace,
 	// such as "backup completed:1234" to indicate which specific backup was completed.
 	//
-	// In these cases, we still need to send the event using the standard listener
-	// name of "backup completed".
-	if strings.Contains(topic, ":") {
-		parts := strings.SplitN(topic, ":", 2)
-
-		if len(parts) == 2 {
-			topic = parts[0]
-		}
-	}
+	// Listeners registered on the bare "backup completed" topic, or on the
+	// "backup completed:*" wildcard, still receive these namespaced events;
+	// the full topic (including the namespace) is preserved on the
+	// delivered Event so subscribers can tell them apart.
+	base := baseTopic(topic)
 
 	b.listenersMx.Lock()
-	defer b.listenersMx.Unlock()
-
-	listeners, ok := b.listeners[topic]
-	if !ok {
-		return
+	b.seq++
+	event := Event{Topic: topic, Data: data, Seq: b.seq}
+	if log, ok := b.logs[base]; ok {
+		log.append(event)
 	}
-	if len(listeners) < 1 {
-		return
+	subs := b.matching(topic, base)
+	b.listenersMx.Unlock()
+
+	// sub.push never blocks (it only ever stages into its bounded inbox,
+	// applying the listener's drop policy rather than waiting for room),
+	// so one stalled PolicyBlock listener can't hold up delivery to the
+	// others in this loop, or hold up this Publish call itself.
+	for _, sub := range subs {
+		sub.push(event)
 	}
+}
 
-	event := Event{Topic: topic, Data: data}
-	for _, listener := range listeners {
-		l := listener
-		go func(l Listener, event Event) {
-			l <- event
-		}(l, event)
+// Metrics returns the current backpressure metrics for listener, and false
+// if the listener isn't registered on the Bus.
+func (b *Bus) Metrics(listener Listener) (ListenerMetrics, bool) {
+	b.listenersMx.Lock()
+	sub, ok := b.subscribers[listener]
+	b.listenersMx.Unlock()
+	if !ok {
+		return ListenerMetrics{}, false
 	}
+	return sub.metrics(), true
 }
 
 // Destroy destroys the Event Bus by unregistering and closing all listeners.
@@ -118,30 +528,18 @@ func (b *Bus) Destroy() {
 	b.listenersMx.Lock()
 	defer b.listenersMx.Unlock()
 
-	// Track what listeners have already been closed. Because the same listener
-	// can be listening on multiple topics, we need a way to essentially
-	// "de-duplicate" all the listeners across all the topics.
-	var closed []Listener
-
-	for _, listeners := range b.listeners {
-		for _, listener := range listeners {
-			if contains(closed, listener) {
-				continue
-			}
-
-			close(listener)
-			closed = append(closed, listener)
-		}
+	// The same listener can be registered against multiple topics, but it
+	// can only ever appear once in b.subscribers, so closing from there
+	// naturally de-duplicates without needing to track what's already
+	// been closed.
+	for listener, sub := range b.subscribers {
+		sub.close()
+		close(listener)
 	}
 
-	b.listeners = make(map[string][]Listener)
-}
-
-func contains(closed []Listener, listener Listener) bool {
-	for _, c := range closed {
-		if c == listener {
-			return true
-		}
-	}
-	return false
+	b.exact = make(map[string][]Listener)
+	b.prefix = make(map[string][]Listener)
+	b.global = nil
+	b.subscribers = make(map[Listener]*subscriber)
+	b.logs = make(map[string]*replayLog)
 }
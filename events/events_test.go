@@ -0,0 +1,257 @@
+package events
+
+import (
+	"testing"
+	"time"
+)
+
+// waitForDropped polls bus.Metrics until listener has dropped at least one
+// event or timeout elapses. Publish only hands events off to the
+// subscriber's inbox; the accept goroutine that applies the policy to the
+// queue (and so may update Dropped) runs asynchronously, so a metrics check
+// immediately after the publishing loop can race ahead of it.
+func waitForDropped(t *testing.T, bus *Bus, listener Listener) ListenerMetrics {
+	t.Helper()
+	deadline := time.After(2 * time.Second)
+	for {
+		metrics, ok := bus.Metrics(listener)
+		if !ok {
+			t.Fatal("expected listener to be registered")
+		}
+		if metrics.Dropped > 0 {
+			return metrics
+		}
+		select {
+		case <-deadline:
+			return metrics
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
+
+func TestPolicyDropNewestDiscardsIncomingEvent(t *testing.T) {
+	bus := NewBus()
+	defer bus.Destroy()
+
+	listener := make(Listener)
+	bus.OnPolicy(listener, PolicyDropNewest, "stats")
+
+	for i := 0; i < defaultQueueSize+5; i++ {
+		bus.Publish("stats", i)
+	}
+
+	if metrics := waitForDropped(t, bus, listener); metrics.Dropped == 0 {
+		t.Fatal("expected some events to be dropped under PolicyDropNewest")
+	}
+
+	first := <-listener
+	if first.Data != 0 {
+		t.Fatalf("expected PolicyDropNewest to preserve the oldest event, got %v", first.Data)
+	}
+}
+
+func TestPolicyDropOldestKeepsMostRecentEvents(t *testing.T) {
+	bus := NewBus()
+	defer bus.Destroy()
+
+	listener := make(Listener)
+	bus.OnPolicy(listener, PolicyDropOldest, "stats")
+
+	total := defaultQueueSize + 5
+	for i := 0; i < total; i++ {
+		bus.Publish("stats", i)
+	}
+
+	if metrics := waitForDropped(t, bus, listener); metrics.Dropped == 0 {
+		t.Fatal("expected some events to be dropped under PolicyDropOldest")
+	}
+
+	// DropOldest never evicts an event once it's been handed to the pump,
+	// so drain everything the pump ever delivers rather than assuming a
+	// fixed count; the last one it delivers should always be the very
+	// last event published, since that one is never evicted in favor of
+	// something older.
+	var lastEvent Event
+	for {
+		select {
+		case lastEvent = <-listener:
+		case <-time.After(100 * time.Millisecond):
+			if lastEvent.Data != total-1 {
+				t.Fatalf("expected PolicyDropOldest to preserve the newest event, got %v", lastEvent.Data)
+			}
+			return
+		}
+	}
+}
+
+func TestPolicyCoalesceKeepsOnlyLatestEvent(t *testing.T) {
+	bus := NewBus()
+	defer bus.Destroy()
+
+	listener := make(Listener)
+	bus.OnPolicy(listener, PolicyCoalesce, "stats")
+
+	// Give the pump a moment to pull the very first event out before we
+	// flood the queue, so the coalesce behavior below is deterministic.
+	bus.Publish("stats", -1)
+	<-listener
+
+	total := defaultQueueSize + 5
+	for i := 0; i < total; i++ {
+		bus.Publish("stats", i)
+	}
+
+	// The pump may already have an event in flight (dequeued, blocked on
+	// send) while a newer one coalesces into the now-empty queue behind
+	// it, so up to two reads can be needed to reach quiescence; only the
+	// last one is guaranteed to be the final coalesced event.
+	var lastEvent Event
+	for {
+		select {
+		case lastEvent = <-listener:
+		case <-time.After(100 * time.Millisecond):
+			if lastEvent.Data != total-1 {
+				t.Fatalf("expected PolicyCoalesce to deliver only the latest event, got %v", lastEvent.Data)
+			}
+			return
+		}
+	}
+}
+
+func TestDestroyDoesNotPanicWithStalledConsumer(t *testing.T) {
+	bus := NewBus()
+
+	// A listener that never reads is exactly the stalled-websocket case
+	// these policies exist for.
+	listener := make(Listener)
+	bus.On(listener, "console output")
+	bus.Publish("console output", "hello")
+
+	done := make(chan interface{}, 1)
+	go func() {
+		defer func() { done <- recover() }()
+		bus.Destroy()
+	}()
+
+	select {
+	case r := <-done:
+		if r != nil {
+			t.Fatalf("Destroy panicked: %v", r)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Destroy did not return; pump likely deadlocked on a stalled listener")
+	}
+}
+
+// TestPublishDoesNotStallOnAStalledBlockListener reproduces the scenario a
+// PolicyBlock listener exists for in the first place: a stalled consumer
+// must never wedge Publish for every other listener on the same topic,
+// including ones (like PolicyCoalesce) registered specifically to survive a
+// slow consumer.
+func TestPublishDoesNotStallOnAStalledBlockListener(t *testing.T) {
+	bus := NewBus()
+	defer bus.Destroy()
+
+	blocked := make(Listener)
+	bus.OnPolicy(blocked, PolicyBlock, "stats")
+	coalesce := make(Listener)
+	bus.OnPolicy(coalesce, PolicyCoalesce, "stats")
+
+	// Fill the blocked listener's queue (nobody ever reads from it) so the
+	// next Publish would have to wait for room under PolicyBlock.
+	for i := 0; i < defaultQueueSize; i++ {
+		bus.Publish("stats", i)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		bus.Publish("stats", "after-fill")
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Publish blocked on the stalled PolicyBlock listener instead of returning")
+	}
+
+	// The coalesce listener may still be draining the fill events, so keep
+	// reading (as TestPolicyCoalesceKeepsOnlyLatestEvent does) until it goes
+	// quiet; only the last event it ever delivers is guaranteed to be the
+	// final one published.
+	var lastEvent Event
+	for {
+		select {
+		case lastEvent = <-coalesce:
+		case <-time.After(100 * time.Millisecond):
+			if lastEvent.Data != "after-fill" {
+				t.Fatalf("expected coalesce listener to see the latest event, got %v", lastEvent.Data)
+			}
+			return
+		}
+	}
+}
+
+// TestPublishBoundsMemoryForAPermanentlyStalledBlockListener covers the
+// other half of the PolicyBlock fix: push must never block (see above),
+// but it also must not grow inbox without limit while accept sits stuck
+// waiting for queue room on a listener that never reads at all. Publishing
+// many times what either buffer can hold must leave QueueDepth bounded,
+// record the overflow in Dropped, and leave Publish itself fast throughout
+// - not just on the first call.
+func TestPublishBoundsMemoryForAPermanentlyStalledBlockListener(t *testing.T) {
+	bus := NewBus()
+	defer bus.Destroy()
+
+	listener := make(Listener)
+	bus.OnPolicy(listener, PolicyBlock, "stats")
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000*defaultQueueSize; i++ {
+			bus.Publish("stats", i)
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Publish did not keep up with a permanently stalled PolicyBlock listener")
+	}
+
+	metrics, ok := bus.Metrics(listener)
+	if !ok {
+		t.Fatal("expected listener to be registered")
+	}
+	if metrics.Dropped == 0 {
+		t.Fatal("expected the bounded inbox to have dropped events for a permanently stalled listener")
+	}
+	if metrics.QueueDepth > 2*defaultQueueSize {
+		t.Fatalf("expected QueueDepth to stay bounded by queue+inbox capacity, got %d", metrics.QueueDepth)
+	}
+}
+
+func TestOffDoesNotPanicWithStalledConsumer(t *testing.T) {
+	bus := NewBus()
+	defer bus.Destroy()
+
+	listener := make(Listener)
+	bus.On(listener, "console output")
+	bus.Publish("console output", "hello")
+
+	done := make(chan interface{}, 1)
+	go func() {
+		defer func() { done <- recover() }()
+		bus.Off(listener, "console output")
+	}()
+
+	select {
+	case r := <-done:
+		if r != nil {
+			t.Fatalf("Off panicked: %v", r)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Off did not return; pump likely deadlocked on a stalled listener")
+	}
+}
@@ -0,0 +1,35 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// PublishJSON marshals v a single time and delivers the resulting payload
+// to every listener subscribed to topic. This avoids re-marshaling v once
+// per listener, which matters when many clients (for example several
+// websocket connections) are subscribed to the same server's events.
+//
+// This uses encoding/json rather than goccy/go-json: nothing else in this
+// module pulls in goccy/go-json, and PublishJSON already marshals v only
+// once regardless of listener count, so swapping encoders wouldn't change
+// the number of allocations per Publish call — only which package pays for
+// them. Revisit if the rest of the daemon standardizes on goccy/go-json.
+func (b *Bus) PublishJSON(topic string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("events: marshal %s: %w", topic, err)
+	}
+	b.Publish(topic, data)
+	return nil
+}
+
+// Decode unmarshals the Event's Data into v. It is intended for events
+// published with PublishJSON, whose Data is the marshaled []byte payload.
+func (e Event) Decode(v interface{}) error {
+	data, ok := e.Data.([]byte)
+	if !ok {
+		return fmt.Errorf("events: cannot decode %s event data of type %T", e.Topic, e.Data)
+	}
+	return json.Unmarshal(data, v)
+}
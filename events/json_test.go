@@ -0,0 +1,61 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestPublishJSONDecodeRoundTrip covers the marshal-once-deliver-to-all
+// path: PublishJSON marshals v a single time, every listener gets the same
+// []byte payload, and Decode unmarshals it back into a value equal to the
+// original.
+func TestPublishJSONDecodeRoundTrip(t *testing.T) {
+	type stats struct {
+		CPU    float64
+		Memory uint64
+	}
+
+	bus := NewBus()
+	defer bus.Destroy()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	a, _ := bus.Subscribe(ctx, StatsEvent)
+	b, _ := bus.Subscribe(ctx, StatsEvent)
+
+	want := stats{CPU: 12.5, Memory: 2048}
+	if err := bus.PublishJSON(StatsEvent, want); err != nil {
+		t.Fatalf("PublishJSON: %v", err)
+	}
+
+	for _, listener := range []<-chan Event{a, b} {
+		select {
+		case event := <-listener:
+			var got stats
+			if err := event.Decode(&got); err != nil {
+				t.Fatalf("Decode: %v", err)
+			}
+			if got != want {
+				t.Fatalf("expected %+v, got %+v", want, got)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("listener never received the published event")
+		}
+	}
+}
+
+// TestEventDecodeRejectsNonJSONPayload covers Decode's error path: an event
+// not published via PublishJSON (so Data isn't a []byte) must return an
+// error identifying the offending type rather than panicking on the failed
+// type assertion.
+func TestEventDecodeRejectsNonJSONPayload(t *testing.T) {
+	event := Event{Topic: StatsEvent, Data: 42}
+
+	var got int
+	err := event.Decode(&got)
+	if err == nil {
+		t.Fatal("expected Decode to return an error for non-[]byte event data")
+	}
+}
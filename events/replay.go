@@ -0,0 +1,84 @@
+package events
+
+// ReplayConfig bounds the replay log kept for a topic so a late-subscribing
+// listener (for example a websocket client reconnecting mid-install) can
+// catch up on what it missed instead of losing it outright.
+type ReplayConfig struct {
+	// MaxEvents bounds the log by event count, evicting the oldest event
+	// once exceeded. Zero means unbounded by count, in which case MaxBytes
+	// must be set instead.
+	MaxEvents int
+	// MaxBytes bounds the log by the cumulative size of []byte/string
+	// event payloads, evicting the oldest events once exceeded. Intended
+	// for high-volume text topics such as ConsoleOutputEvent, where
+	// bounding by count alone doesn't limit memory use.
+	MaxBytes int
+}
+
+// ConfigureReplay enables (or replaces) the bounded replay log for topic,
+// so that SubscribeFrom can replay events missed by a reconnecting
+// listener. Call with a zero ReplayConfig to disable replay for topic.
+func (b *Bus) ConfigureReplay(topic string, cfg ReplayConfig) {
+	b.listenersMx.Lock()
+	defer b.listenersMx.Unlock()
+
+	if cfg.MaxEvents == 0 && cfg.MaxBytes == 0 {
+		delete(b.logs, topic)
+		return
+	}
+	b.logs[topic] = &replayLog{cfg: cfg}
+}
+
+// replayLog is a bounded, in-order buffer of recently published events for
+// a single topic.
+type replayLog struct {
+	cfg    ReplayConfig
+	events []Event
+	bytes  int
+}
+
+// append records event, evicting the oldest buffered events until the log
+// is back within cfg's bounds. Callers must hold the owning Bus'
+// listenersMx.
+func (r *replayLog) append(event Event) {
+	r.events = append(r.events, event)
+	r.bytes += payloadSize(event.Data)
+
+	for len(r.events) > 0 && r.overflowing() {
+		r.bytes -= payloadSize(r.events[0].Data)
+		r.events = r.events[1:]
+	}
+}
+
+func (r *replayLog) overflowing() bool {
+	if r.cfg.MaxEvents > 0 && len(r.events) > r.cfg.MaxEvents {
+		return true
+	}
+	if r.cfg.MaxBytes > 0 && r.bytes > r.cfg.MaxBytes {
+		return true
+	}
+	return false
+}
+
+// since returns the buffered events with a Seq greater than cursor, in
+// publish order. Callers must hold the owning Bus' listenersMx.
+func (r *replayLog) since(cursor uint64) []Event {
+	out := make([]Event, 0, len(r.events))
+	for _, e := range r.events {
+		if e.Seq > cursor {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func payloadSize(data interface{}) int {
+	switch v := data.(type) {
+	case []byte:
+		return len(v)
+	case string:
+		return len(v)
+	default:
+		return 0
+	}
+}
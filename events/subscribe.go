@@ -0,0 +1,114 @@
+package events
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Subscribe registers a new listener for the given topics and returns a
+// channel of events together with an unsubscribe function. The listener is
+// automatically unregistered and its channel closed when ctx is cancelled
+// or the returned function is called; calling it more than once, or letting
+// both ctx cancellation and an explicit call race, is safe.
+//
+// This exists so callers no longer have to build their own Listener, track
+// every topic they registered for, and remember to call Off on shutdown.
+func (b *Bus) Subscribe(ctx context.Context, topics ...string) (<-chan Event, func()) {
+	listener := make(Listener, defaultQueueSize)
+	b.On(listener, topics...)
+	return listener, b.unsubscriber(ctx, listener, topics)
+}
+
+// SubscribeFunc behaves like Subscribe, but invokes fn for every event
+// instead of returning a channel for the caller to range over. The
+// returned function stops delivery the same way Subscribe's does.
+func (b *Bus) SubscribeFunc(ctx context.Context, fn func(Event), topics ...string) func() {
+	events, unsubscribe := b.Subscribe(ctx, topics...)
+	go func() {
+		for event := range events {
+			fn(event)
+		}
+	}()
+	return unsubscribe
+}
+
+// SubscribeFrom behaves like Subscribe, but first replays any events
+// buffered for topics (see ConfigureReplay) with a Seq greater than cursor,
+// then transitions to live delivery. Subscribing to wildcardAll ("*")
+// replays every topic with an active log, not just one named "*" (there is
+// no such log). Registration and the replay snapshot are taken under a
+// single lock, so a Publish racing with this call can never be missed
+// (it's either in the snapshot or delivered live once the listener is
+// registered) or delivered twice. The buffered events themselves are
+// pushed to the listener after releasing that lock: push never blocks
+// (see subscriber.push), so even a large backlog (e.g. a bytes-capped
+// "console output" log) replayed onto a stalled listener can't stall any
+// other Publish/Subscribe/Off on the Bus.
+func (b *Bus) SubscribeFrom(ctx context.Context, cursor uint64, topics ...string) (<-chan Event, func()) {
+	listener := make(Listener, defaultQueueSize)
+
+	b.listenersMx.Lock()
+	sub := newSubscriber(listener, PolicyBlock, defaultQueueSize)
+	b.subscribers[listener] = sub
+
+	var replay []Event
+	seenLogs := make(map[string]struct{})
+	replayLog := func(base string) {
+		if _, ok := seenLogs[base]; ok {
+			return
+		}
+		seenLogs[base] = struct{}{}
+		if log, ok := b.logs[base]; ok {
+			replay = append(replay, log.since(cursor)...)
+		}
+	}
+	for _, topic := range topics {
+		b.on(topic, listener)
+
+		if topic == wildcardAll {
+			for base := range b.logs {
+				replayLog(base)
+			}
+			continue
+		}
+		replayLog(baseTopic(strings.TrimSuffix(topic, wildcardSuffix)))
+	}
+	b.listenersMx.Unlock()
+
+	// Multiple topics can draw from different logs; restore publish order
+	// across them before handing events to the listener.
+	sort.Slice(replay, func(i, j int) bool { return replay[i].Seq < replay[j].Seq })
+	for _, event := range replay {
+		sub.push(event)
+	}
+
+	return listener, b.unsubscriber(ctx, listener, topics)
+}
+
+// unsubscriber builds the done-channel-guarded unsubscribe func shared by
+// Subscribe and SubscribeFrom: it tears the listener down at most once,
+// whether triggered by ctx cancellation or an explicit call.
+func (b *Bus) unsubscriber(ctx context.Context, listener Listener, topics []string) func() {
+	var once sync.Once
+	done := make(chan struct{})
+	unsubscribe := func() {
+		once.Do(func() {
+			close(done)
+			b.Off(listener, topics...)
+		})
+	}
+
+	if ctx != nil {
+		go func() {
+			select {
+			case <-ctx.Done():
+				unsubscribe()
+			case <-done:
+			}
+		}()
+	}
+
+	return unsubscribe
+}
@@ -0,0 +1,16 @@
+package events
+
+// Topic constants for the events published across the daemon, so that a
+// typo in a topic string becomes a compile error instead of a silently
+// dropped (or silently never-delivered) event.
+const (
+	DaemonMessageEvent          = "daemon message"
+	InstallOutputEvent          = "install output"
+	InstallStartedEvent         = "install started"
+	InstallCompletedEvent       = "install completed"
+	ConsoleOutputEvent          = "console output"
+	StatsEvent                  = "stats"
+	StatusEvent                 = "status"
+	BackupCompletedEvent        = "backup completed"
+	BackupRestoreCompletedEvent = "backup restore completed"
+)
@@ -0,0 +1,175 @@
+package events
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"sync"
+)
+
+// Transport is a pluggable cross-process delivery mechanism a Bus can
+// mirror topics through via Bridge, so events can fan out to (or in from)
+// other Wings instances, an API gateway, or a metrics collector instead of
+// staying strictly in-process.
+type Transport interface {
+	// Publish sends the raw, already-encoded payload for topic to the
+	// remote transport.
+	Publish(topic string, payload []byte) error
+	// Subscribe delivers payloads received for topic to fn until ctx is
+	// cancelled.
+	Subscribe(ctx context.Context, topic string, fn func(payload []byte)) error
+}
+
+// bridgeEnvelope is the wire format used when mirroring events across a
+// Transport. Topic is kept in full, including any ":<id>" namespace
+// suffix, so panel-side consumers can still filter by ID after a round
+// trip through the transport.
+type bridgeEnvelope struct {
+	Origin string          `json:"origin"`
+	Topic  string          `json:"topic"`
+	Data   json.RawMessage `json:"data"`
+}
+
+func generateOriginID() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err == nil {
+		return hex.EncodeToString(buf)
+	}
+	// crypto/rand failing is exceedingly unlikely; the fallback is a fixed
+	// value, so Bridge calls that all hit it share one origin ID and drop
+	// each other's events as their own.
+	return "wings-bridge"
+}
+
+// pendingInjects tracks the wire payloads a single Bridge call has just
+// published locally on behalf of the transport, so its own outbound
+// forwarder can recognize and skip them instead of immediately sending
+// them straight back out. Without this, a remote event republished
+// in-process would be picked up by the same Bridge's listener and
+// re-forwarded to the transport on every hop, ping-ponging between nodes
+// (or amplifying) rather than being absorbed once locally. It's owned
+// per-Bridge-call rather than being a package-level origin, so bridging
+// two topics (or two Buses) in the same process can't suppress each
+// other's events.
+type pendingInjects struct {
+	mu    sync.Mutex
+	count map[string]int
+}
+
+func newPendingInjects() *pendingInjects {
+	return &pendingInjects{count: make(map[string]int)}
+}
+
+func (p *pendingInjects) add(key string) {
+	p.mu.Lock()
+	p.count[key]++
+	p.mu.Unlock()
+}
+
+// take reports whether key was pending and, if so, consumes one
+// occurrence of it.
+func (p *pendingInjects) take(key string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	n, ok := p.count[key]
+	if !ok {
+		return false
+	}
+	if n <= 1 {
+		delete(p.count, key)
+	} else {
+		p.count[key] = n - 1
+	}
+	return true
+}
+
+func injectKey(topic string, data []byte) string {
+	return topic + "\x00" + string(data)
+}
+
+// marshalEventData returns the JSON encoding to use for an event's Data
+// when forwarding it to a Transport. Data that is already raw JSON bytes -
+// as produced by PublishJSON, or by Bridge's own inbound handler below -
+// is used as-is rather than marshaled a second time: encoding/json treats
+// []byte as opaque binary and would base64-encode it, corrupting an
+// already-JSON payload instead of reproducing it.
+func marshalEventData(data interface{}) (json.RawMessage, error) {
+	if raw, ok := data.([]byte); ok {
+		return raw, nil
+	}
+	return json.Marshal(data)
+}
+
+// Bridge mirrors topics between the Bus and transport: events published
+// locally are forwarded to transport, and events the transport receives
+// for those topics are published locally. Each call gets its own origin
+// ID and injected-event tracking, so it is safe to Bridge the same Bus
+// more than once, or to bridge two Buses in the same process, without
+// them suppressing each other. The returned func stops the bridge in
+// both directions.
+//
+// Events received over transport are republished locally with Data set to
+// the raw marshaled JSON payload, the same []byte contract PublishJSON
+// uses, rather than decoded into a native Go value: that way a bridged
+// event still round-trips through Event.Decode the same as a local
+// PublishJSON one would, regardless of which side of the bridge it was
+// published on.
+func (b *Bus) Bridge(ctx context.Context, transport Transport, topics ...string) (func(), error) {
+	origin := generateOriginID()
+	pending := newPendingInjects()
+
+	ctx, cancel := context.WithCancel(ctx)
+	listener, unsubscribe := b.Subscribe(ctx, topics...)
+	stop := func() {
+		cancel()
+		unsubscribe()
+	}
+
+	for _, topic := range topics {
+		wire := strings.TrimSuffix(strings.TrimSuffix(topic, wildcardSuffix), wildcardAll)
+		if wire == "" {
+			continue
+		}
+		if err := transport.Subscribe(ctx, wire, func(payload []byte) {
+			var env bridgeEnvelope
+			if err := json.Unmarshal(payload, &env); err != nil || env.Origin == origin {
+				return
+			}
+			data := []byte(env.Data)
+			// Mark this payload as injected by us before publishing it, so
+			// our own outbound forwarder below drops it instead of
+			// immediately sending it back out to transport. Republishing
+			// the raw wire bytes unchanged (instead of decoding them) means
+			// the forwarder's marshalEventData reproduces these exact
+			// bytes, so the key always matches - no re-marshal involved on
+			// either side to risk reordering anything.
+			pending.add(injectKey(env.Topic, data))
+			b.Publish(env.Topic, data)
+		}); err != nil {
+			stop()
+			return nil, err
+		}
+	}
+
+	go func() {
+		for event := range listener {
+			data, err := marshalEventData(event.Data)
+			if err != nil {
+				continue
+			}
+			if pending.take(injectKey(event.Topic, data)) {
+				continue
+			}
+			payload, err := json.Marshal(bridgeEnvelope{Origin: origin, Topic: event.Topic, Data: data})
+			if err != nil {
+				continue
+			}
+			_ = transport.Publish(baseTopic(event.Topic), payload)
+		}
+	}()
+
+	return stop, nil
+}
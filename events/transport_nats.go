@@ -0,0 +1,38 @@
+package events
+
+import (
+	"context"
+
+	"github.com/nats-io/nats.go"
+)
+
+// NATSTransport bridges Bus events over an existing NATS connection.
+type NATSTransport struct {
+	conn *nats.Conn
+}
+
+// NewNATSTransport wraps conn for use as a Bus Transport. The connection's
+// lifecycle remains the caller's responsibility.
+func NewNATSTransport(conn *nats.Conn) *NATSTransport {
+	return &NATSTransport{conn: conn}
+}
+
+// Publish implements Transport.
+func (t *NATSTransport) Publish(topic string, payload []byte) error {
+	return t.conn.Publish(topic, payload)
+}
+
+// Subscribe implements Transport.
+func (t *NATSTransport) Subscribe(ctx context.Context, topic string, fn func(payload []byte)) error {
+	sub, err := t.conn.Subscribe(topic, func(msg *nats.Msg) {
+		fn(msg.Data)
+	})
+	if err != nil {
+		return err
+	}
+	go func() {
+		<-ctx.Done()
+		_ = sub.Unsubscribe()
+	}()
+	return nil
+}
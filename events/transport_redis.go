@@ -0,0 +1,44 @@
+package events
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisTransport bridges Bus events over Redis Pub/Sub.
+type RedisTransport struct {
+	client *redis.Client
+}
+
+// NewRedisTransport wraps client for use as a Bus Transport. The client's
+// lifecycle remains the caller's responsibility.
+func NewRedisTransport(client *redis.Client) *RedisTransport {
+	return &RedisTransport{client: client}
+}
+
+// Publish implements Transport.
+func (t *RedisTransport) Publish(topic string, payload []byte) error {
+	return t.client.Publish(context.Background(), topic, payload).Err()
+}
+
+// Subscribe implements Transport.
+func (t *RedisTransport) Subscribe(ctx context.Context, topic string, fn func(payload []byte)) error {
+	pubsub := t.client.Subscribe(ctx, topic)
+	ch := pubsub.Channel()
+	go func() {
+		defer pubsub.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-ch:
+				if !ok {
+					return
+				}
+				fn([]byte(msg.Payload))
+			}
+		}
+	}()
+	return nil
+}
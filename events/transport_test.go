@@ -0,0 +1,175 @@
+package events
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeBroker is an in-memory stand-in for a real pub/sub network (NATS,
+// Redis, ...) shared by every fakeTransport in a test, so multiple Bridge
+// calls can exchange payloads with each other the way separate Wings
+// nodes would over the wire.
+type fakeBroker struct {
+	mu   sync.Mutex
+	subs map[string][]func(payload []byte)
+}
+
+func newFakeBroker() *fakeBroker {
+	return &fakeBroker{subs: make(map[string][]func(payload []byte))}
+}
+
+func (b *fakeBroker) publish(topic string, payload []byte) {
+	b.mu.Lock()
+	fns := append([]func([]byte){}, b.subs[topic]...)
+	b.mu.Unlock()
+
+	for _, fn := range fns {
+		fn(payload)
+	}
+}
+
+func (b *fakeBroker) subscribe(topic string, fn func(payload []byte)) {
+	b.mu.Lock()
+	b.subs[topic] = append(b.subs[topic], fn)
+	b.mu.Unlock()
+}
+
+// fakeTransport implements Transport against a fakeBroker and counts how
+// many times it published, so tests can assert a Bridge never re-forwards
+// an event it only just received from the transport.
+type fakeTransport struct {
+	broker    *fakeBroker
+	published int64
+}
+
+func (t *fakeTransport) Publish(topic string, payload []byte) error {
+	atomic.AddInt64(&t.published, 1)
+	t.broker.publish(topic, payload)
+	return nil
+}
+
+func (t *fakeTransport) Subscribe(ctx context.Context, topic string, fn func(payload []byte)) error {
+	t.broker.subscribe(topic, fn)
+	return nil
+}
+
+func (t *fakeTransport) publishCount() int64 {
+	return atomic.LoadInt64(&t.published)
+}
+
+// TestBridgeDeliversAcrossNodesWithoutLooping bridges two Buses (standing
+// in for two Wings nodes) through a shared fakeBroker and checks both
+// halves of the loop-suppression contract: the event published on node A
+// must reach node B, and node B's Bridge must not turn around and
+// re-publish that same injected event back onto the transport.
+func TestBridgeDeliversAcrossNodesWithoutLooping(t *testing.T) {
+	broker := newFakeBroker()
+
+	nodeA := NewBus()
+	defer nodeA.Destroy()
+	nodeB := NewBus()
+	defer nodeB.Destroy()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	transportA := &fakeTransport{broker: broker}
+	transportB := &fakeTransport{broker: broker}
+
+	if _, err := nodeA.Bridge(ctx, transportA, BackupCompletedEvent); err != nil {
+		t.Fatalf("bridging node A: %v", err)
+	}
+	if _, err := nodeB.Bridge(ctx, transportB, BackupCompletedEvent); err != nil {
+		t.Fatalf("bridging node B: %v", err)
+	}
+
+	listener, _ := nodeB.Subscribe(ctx, BackupCompletedEvent)
+
+	nodeA.Publish(BackupCompletedEvent, "1234")
+
+	select {
+	case event := <-listener:
+		// Bridge republishes Data as the raw marshaled JSON payload, the
+		// same []byte contract PublishJSON uses, so Decode works on a
+		// bridged event the same as it would on a local PublishJSON one.
+		var payload string
+		if err := event.Decode(&payload); err != nil {
+			t.Fatalf("Decode: %v", err)
+		}
+		if payload != "1234" {
+			t.Fatalf("expected bridged payload %q, got %q", "1234", payload)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("node B never received the event bridged from node A")
+	}
+
+	// Give node B's forwarder goroutine a chance to (incorrectly) echo
+	// the event it just received from the transport back onto it.
+	time.Sleep(100 * time.Millisecond)
+
+	if got := transportB.publishCount(); got != 0 {
+		t.Fatalf("expected node B's bridge not to re-forward the injected event, got %d publish(es)", got)
+	}
+}
+
+// TestBridgeLoopSuppressionSurvivesKeyReordering bridges a struct payload
+// whose fields aren't already in alphabetical order. encoding/json always
+// marshals map keys in sorted order, regardless of the field order the
+// original sender used, so a loop-suppression scheme that ever decodes and
+// re-marshals a bridged payload risks a key mismatch for any such payload.
+// Bridge instead republishes (and keys on) the raw marshaled bytes
+// unchanged, which this also exercises via Decode.
+func TestBridgeLoopSuppressionSurvivesKeyReordering(t *testing.T) {
+	type payload struct {
+		Zebra int
+		Alpha string
+	}
+
+	broker := newFakeBroker()
+
+	nodeA := NewBus()
+	defer nodeA.Destroy()
+	nodeB := NewBus()
+	defer nodeB.Destroy()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	transportA := &fakeTransport{broker: broker}
+	transportB := &fakeTransport{broker: broker}
+
+	if _, err := nodeA.Bridge(ctx, transportA, BackupCompletedEvent); err != nil {
+		t.Fatalf("bridging node A: %v", err)
+	}
+	if _, err := nodeB.Bridge(ctx, transportB, BackupCompletedEvent); err != nil {
+		t.Fatalf("bridging node B: %v", err)
+	}
+
+	listener, _ := nodeB.Subscribe(ctx, BackupCompletedEvent)
+
+	nodeA.Publish(BackupCompletedEvent, payload{Zebra: 1, Alpha: "a"})
+
+	select {
+	case event := <-listener:
+		var got payload
+		if err := event.Decode(&got); err != nil {
+			t.Fatalf("Decode: %v", err)
+		}
+		if got != (payload{Zebra: 1, Alpha: "a"}) {
+			t.Fatalf("expected bridged payload %+v, got %+v", payload{Zebra: 1, Alpha: "a"}, got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("node B never received the event bridged from node A")
+	}
+
+	// Give node B's forwarder goroutine a chance to (incorrectly) echo
+	// the event it just received from the transport back onto it.
+	time.Sleep(100 * time.Millisecond)
+
+	if got := transportB.publishCount(); got != 0 {
+		t.Fatalf("expected node B's bridge not to re-forward the injected event, got %d publish(es)", got)
+	}
+}
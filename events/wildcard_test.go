@@ -0,0 +1,101 @@
+package events
+
+import "testing"
+
+// TestWildcardAllReceivesEveryTopic covers On(listener, "*"): a global
+// listener must be delivered events from any topic, with Event.Topic
+// preserved exactly as published.
+func TestWildcardAllReceivesEveryTopic(t *testing.T) {
+	bus := NewBus()
+	defer bus.Destroy()
+
+	listener := make(Listener, 2)
+	bus.On(listener, "*")
+
+	bus.Publish(StatsEvent, "cpu")
+	bus.Publish(BackupCompletedEvent+":1234", "done")
+
+	first := <-listener
+	if first.Topic != StatsEvent {
+		t.Fatalf("expected topic %q, got %q", StatsEvent, first.Topic)
+	}
+	second := <-listener
+	if second.Topic != BackupCompletedEvent+":1234" {
+		t.Fatalf("expected topic %q, got %q", BackupCompletedEvent+":1234", second.Topic)
+	}
+}
+
+// TestWildcardSuffixReceivesNamespace covers On(listener,
+// "backup completed:*"): a prefix listener must receive every
+// "backup completed:<id>" event regardless of <id>, with the full
+// namespaced topic preserved, but must not receive unrelated topics.
+func TestWildcardSuffixReceivesNamespace(t *testing.T) {
+	bus := NewBus()
+	defer bus.Destroy()
+
+	listener := make(Listener, 2)
+	bus.On(listener, BackupCompletedEvent+wildcardSuffix)
+
+	bus.Publish(BackupCompletedEvent+":1234", "done")
+	bus.Publish(BackupCompletedEvent+":5678", "done")
+	bus.Publish(StatsEvent, "cpu")
+
+	for _, wantID := range []string{"1234", "5678"} {
+		event := <-listener
+		wantTopic := BackupCompletedEvent + ":" + wantID
+		if event.Topic != wantTopic {
+			t.Fatalf("expected topic %q, got %q", wantTopic, event.Topic)
+		}
+	}
+
+	select {
+	case event := <-listener:
+		t.Fatalf("expected no further events, got %#v", event)
+	default:
+	}
+}
+
+// TestWildcardSuffixReceivesBareTopic covers the other direction of the
+// namespace contract: a "backup completed:*" listener also receives the
+// bare "backup completed" topic (with no ID suffix at all), since it's the
+// same namespace with nothing more specific to report.
+func TestWildcardSuffixReceivesBareTopic(t *testing.T) {
+	bus := NewBus()
+	defer bus.Destroy()
+
+	listener := make(Listener, 1)
+	bus.On(listener, BackupCompletedEvent+wildcardSuffix)
+
+	bus.Publish(BackupCompletedEvent, "done")
+
+	event := <-listener
+	if event.Topic != BackupCompletedEvent {
+		t.Fatalf("expected topic %q, got %q", BackupCompletedEvent, event.Topic)
+	}
+}
+
+// TestWildcardExactOverlapDeliversOnce covers a listener registered on
+// multiple routes that all match the same published event - the exact
+// namespaced topic, the "*" prefix for that namespace, and the global "*" -
+// which must still only be delivered to once per event, not once per
+// matching route.
+func TestWildcardExactOverlapDeliversOnce(t *testing.T) {
+	bus := NewBus()
+	defer bus.Destroy()
+
+	listener := make(Listener, 1)
+	bus.On(listener, BackupCompletedEvent+":1234", BackupCompletedEvent+wildcardSuffix, wildcardAll)
+
+	bus.Publish(BackupCompletedEvent+":1234", "done")
+
+	event := <-listener
+	if event.Topic != BackupCompletedEvent+":1234" {
+		t.Fatalf("expected topic %q, got %q", BackupCompletedEvent+":1234", event.Topic)
+	}
+
+	select {
+	case event := <-listener:
+		t.Fatalf("expected the overlapping routes to deliver only once, got a second event %#v", event)
+	default:
+	}
+}